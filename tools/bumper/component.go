@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/go-git/go-git/v5"
+)
+
+// defaultDescribeMaxWalk bounds how many commits describeCommit will
+// visit before giving up and falling back to a short SHA. It's the
+// fallback for components that don't set DescribeMaxWalk.
+const defaultDescribeMaxWalk = 1000
+
+// component describes a single upstream dependency the bumper tracks:
+// where it lives, which branch to follow, and how it's described/verified.
+type component struct {
+	Name   string `yaml:"name"`
+	URL    string `yaml:"url"`
+	Branch string `yaml:"branch"`
+
+	// Match/Exclude are glob patterns applied to tag names and
+	// FirstParent restricts the walk to first parents; all three are
+	// plumbed into describeForComponent. DescribeMaxWalk overrides
+	// defaultDescribeMaxWalk when set.
+	Match           []string `yaml:"match"`
+	Exclude         []string `yaml:"exclude"`
+	FirstParent     bool     `yaml:"first_parent"`
+	DescribeMaxWalk int      `yaml:"describe_max_walk"`
+
+	// RequireSignedTag and RequireSignedCommit opt a component into
+	// refusing to bump unless the chosen tag/tip commit verifies against
+	// TrustedSigners, an armored keyring file (see signature.go).
+	RequireSignedTag    bool   `yaml:"require_signed_tag"`
+	RequireSignedCommit bool   `yaml:"require_signed_commit"`
+	TrustedSigners      string `yaml:"trusted_signers"`
+}
+
+// gitRepo wraps a local clone of a component's repository.
+type gitRepo struct {
+	repo     *git.Repository
+	localDir string
+}
+
+// gitComponent ties a component's config to its local clone and the
+// gitHost used to discover new tags/commits to bump to.
+type gitComponent struct {
+	configParams *component
+	gitHost      gitHost
+	gitRepo      *gitRepo
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+)
+
+// describeHash implements `git describe --tags --always` natively: it
+// walks parents from commitHash until it reaches a tagged ancestor,
+// falling back to a short SHA when none is found within maxWalk
+// commits. match/exclude are glob patterns (as matched by path.Match)
+// applied to tag names, and firstParent restricts the walk to each
+// commit's first parent, matching the component config knobs of the
+// same name.
+func describeHash(repoDir, commitHash string, match, exclude []string, firstParent bool, maxWalk int) (string, error) {
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open repo")
+	}
+
+	tagsByHash, err := buildTagsByHash(repo, match, exclude)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to index repo tags")
+	}
+
+	commit, err := repo.CommitObject(plumbing.NewHash(commitHash))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve commit")
+	}
+
+	return describeCommit(commit, tagsByHash, firstParent, maxWalk)
+}
+
+// describeForComponent calls describeHash using cfg's match/exclude/
+// first_parent/describe_max_walk knobs, falling back to
+// defaultDescribeMaxWalk when cfg.DescribeMaxWalk is unset (the zero
+// value), so components don't have to opt into a walk bound.
+func describeForComponent(repoDir, commitHash string, cfg *component) (string, error) {
+	maxWalk := cfg.DescribeMaxWalk
+	if maxWalk == 0 {
+		maxWalk = defaultDescribeMaxWalk
+	}
+
+	return describeHash(repoDir, commitHash, cfg.Match, cfg.Exclude, cfg.FirstParent, maxWalk)
+}
+
+// buildTagsByHash indexes tags passing the match/exclude filter by the
+// hash of the commit they ultimately point at, resolving annotated tag
+// objects to their target commit.
+func buildTagsByHash(repo *git.Repository, match, exclude []string) (map[plumbing.Hash]string, error) {
+	tagsByHash := map[plumbing.Hash]string{}
+
+	tagIter, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	defer tagIter.Close()
+
+	err = tagIter.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if !tagPasses(name, match, exclude) {
+			return nil
+		}
+
+		tagsByHash[resolveToCommitHash(repo, ref.Hash())] = name
+		return nil
+	})
+
+	return tagsByHash, err
+}
+
+// tagPasses reports whether a tag name should be considered for describe:
+// it must match at least one of match (when match is non-empty) and none
+// of exclude.
+func tagPasses(name string, match, exclude []string) bool {
+	if len(match) > 0 {
+		matched := false
+		for _, pattern := range match {
+			if ok, _ := path.Match(pattern, name); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, name); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// describeCommit does a breadth-first walk of commit ancestors, closest
+// first, and formats the nearest tagged ancestor as `<tag>-<depth>-g<sha>`,
+// where sha is the described commit's own short hash (or bare `<tag>` at
+// depth 0), matching `git describe`'s output. When
+// firstParent is set, merge commits' non-first parents are never
+// visited, mirroring `git describe --first-parent`.
+func describeCommit(commit *object.Commit, tagsByHash map[plumbing.Hash]string, firstParent bool, maxWalk int) (string, error) {
+	type queued struct {
+		commit *object.Commit
+		depth  int
+	}
+
+	visited := map[plumbing.Hash]bool{commit.Hash: true}
+	queue := []queued{{commit: commit, depth: 0}}
+
+	for visitCount := 0; len(queue) > 0 && visitCount < maxWalk; visitCount++ {
+		current := queue[0]
+		queue = queue[1:]
+
+		if tag, ok := tagsByHash[current.commit.Hash]; ok {
+			if current.depth == 0 {
+				return tag, nil
+			}
+			return fmt.Sprintf("%s-%d-g%s", tag, current.depth, commit.Hash.String()[:7]), nil
+		}
+
+		parents, err := commitParents(current.commit, firstParent)
+		if err != nil {
+			return "", err
+		}
+
+		for _, parent := range parents {
+			if !visited[parent.Hash] {
+				visited[parent.Hash] = true
+				queue = append(queue, queued{commit: parent, depth: current.depth + 1})
+			}
+		}
+	}
+
+	return commit.Hash.String()[:7], nil
+}
+
+// commitParents returns commit's parents, restricted to just the first
+// parent when firstParent is set.
+func commitParents(commit *object.Commit, firstParent bool) ([]*object.Commit, error) {
+	if firstParent {
+		if commit.NumParents() == 0 {
+			return nil, nil
+		}
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		return []*object.Commit{parent}, nil
+	}
+
+	var parents []*object.Commit
+	err := commit.Parents().ForEach(func(parent *object.Commit) error {
+		parents = append(parents, parent)
+		return nil
+	})
+
+	return parents, err
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var _ = Describe("describeForComponent", func() {
+	var (
+		repoDir      string
+		repo         *git.Repository
+		w            *git.Worktree
+		tagCommitMap map[string]string
+	)
+
+	BeforeEach(func() {
+		var err error
+		repoDir, err = ioutil.TempDir("", "bumper-describe-test")
+		Expect(err).ToNot(HaveOccurred())
+
+		tagCommitMap = map[string]string{}
+		newLocalGitRepo(repoDir, tagCommitMap)
+
+		repo, err = git.PlainOpen(repoDir)
+		Expect(err).ToNot(HaveOccurred())
+
+		w, err = repo.Worktree()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(repoDir)).To(Succeed())
+	})
+
+	It("describes a tagged commit exactly, with no suffix", func() {
+		result, err := describeForComponent(repoDir, tagCommitMap["v0.0.1"], &component{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal("v0.0.1"))
+	})
+
+	It("describes an untagged commit as <tag>-<depth>-g<sha>", func() {
+		result, err := describeForComponent(repoDir, tagCommitMap["dummy_tag_latest_master"], &component{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HavePrefix("v0.0.2-1-g"))
+	})
+
+	It("skips tags excluded by Exclude and falls back to the next ancestor", func() {
+		cfg := &component{Match: []string{"v*"}, Exclude: []string{"v0.0.2"}}
+		result, err := describeForComponent(repoDir, tagCommitMap["dummy_tag_latest_master"], cfg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HavePrefix("v0.0.2-rc1-"))
+	})
+
+	It("skips tags that don't pass Match and falls back to the next ancestor", func() {
+		cfg := &component{Match: []string{"v*"}}
+		result, err := describeForComponent(repoDir, tagCommitMap["dummy_tag_latest_release-v1.0.0"], cfg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HavePrefix("v1.0.1-2-g"))
+	})
+
+	It("falls back to a short SHA when DescribeMaxWalk is exceeded before a tag is found", func() {
+		cfg := &component{DescribeMaxWalk: 1}
+		result, err := describeForComponent(repoDir, tagCommitMap["dummy_tag_latest_master"], cfg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(tagCommitMap["dummy_tag_latest_master"][:7]))
+	})
+
+	It("ignores tags only reachable through a merge when FirstParent is set", func() {
+		featureTagCommit := tagCommitMap["dummy_tag_latest_master"]
+		createBranchFrom(repo, "feature", plumbing.NewHash(featureTagCommit))
+		createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "feature_work", "v9.0.0-feature", "feature", nil)
+
+		mergeCommit := createMergeCommit(w, repo, repoDir, "merge_commit", "master", plumbing.NewHash(tagCommitMap["v9.0.0-feature"]))
+
+		firstParent, err := describeForComponent(repoDir, mergeCommit.String(), &component{FirstParent: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(firstParent).ToNot(ContainSubstring("v9.0.0-feature"))
+		Expect(firstParent).To(HavePrefix("v0.0.2-2-g"))
+
+		allParents, err := describeForComponent(repoDir, mergeCommit.String(), &component{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allParents).To(Equal(fmt.Sprintf("v9.0.0-feature-1-g%s", mergeCommit.String()[:7])))
+	})
+})
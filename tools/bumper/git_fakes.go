@@ -1,10 +1,9 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"os/exec"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -15,189 +14,120 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	"github.com/google/go-github/v32/github"
 	"github.com/pkg/errors"
 	"github.com/thanhpk/randstr"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
 )
 
-type mockGithubApi struct {
+// mockGitHost satisfies gitHost directly against a local go-git
+// repository (see githost.go), standing in for whichever real
+// implementation (githubHost, gitlabHost, bitbucketCloudHost,
+// bitbucketServerHost) the component's URL would otherwise select.
+type mockGitHost struct {
 	repoDir string
 }
 
-func (g mockGithubApi) ListMatchingRefs(owner, repo string, opts *github.ReferenceListOptions) ([]*github.Reference, *github.Response, error) {
-	gitCommitObjList, err := gitLogJson(g.repoDir, "")
+func (g mockGitHost) ListMatchingRefs(pattern string) ([]gitRef, error) {
+	commitRefList, err := gitLog(g.repoDir, "")
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed performing mock git log")
+		return nil, errors.Wrap(err, "failed performing mock git log")
 	}
 
-	return convertLogToReferenceList(gitCommitObjList, opts.Ref), nil, nil
+	return matchingRefs(commitRefList, pattern), nil
 }
 
-func (g mockGithubApi) ListCommits(owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
-	gitCommitObjList, err := gitLogJson(g.repoDir, opts.SHA)
+func (g mockGitHost) ListCommits(branch string) ([]gitCommit, error) {
+	commitRefList, err := gitLog(g.repoDir, branch)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed performing mock git log")
+		return nil, errors.Wrap(err, "failed performing mock git log")
 	}
 
-	return convertLogToRepositoryCommitList(gitCommitObjList), nil, nil
+	return toGitCommits(commitRefList), nil
 }
 
-func (g mockGithubApi) GetRef(owner string, repo string, ref string) (*github.Reference, *github.Response, error) {
-	gitCommitObjList, err := gitLogJson(g.repoDir, "")
+func (g mockGitHost) GetRef(ref string) (gitRef, error) {
+	commitRefList, err := gitLog(g.repoDir, "")
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed performing mock git log")
+		return gitRef{}, errors.Wrap(err, "failed performing mock git log")
 	}
 
-	githubRef, err := getRefFromCommitObjList(gitCommitObjList, ref)
-	return githubRef, nil, err
+	return refFromCommitRefList(commitRefList, ref)
 }
 
-type gitCommitMock struct {
-	Commit string `json:"commit"`
-	Refs   string `json:"refs"`
-}
-
-var GITFORMAT = `--pretty=format:{
-  "commit": "%H",
-  "parent": "%P",
-  "refs": "%D",
-  "subject": "%s",
-  "author": { "name": "%aN", "email": "%aE", "date": "%ad" },
-  "commiter": { "name": "%cN", "email": "%cE", "date": "%cd" }
- },`
-
-func gitCommand(args []string) (string, error) {
-
-	cmd := exec.Command("git", args...)
-	out, err := cmd.Output()
+func (g mockGitHost) DefaultBranch() (string, error) {
+	repo, err := git.PlainOpen(g.repoDir)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to run git command: git %s", args)
+		return "", errors.Wrap(err, "failed to open mock repo")
 	}
 
-	logOut := string(out)
-	logOut = logOut[:len(logOut)-1] // Remove the last ","
-
-	return logOut, err
-}
-
-func gitLogJson(repo, branchName string) ([]gitCommitMock, error) {
-	args := []string{
-		"-C",
-		repo,
-		"log",
-		"--date=iso-strict",
-		"--first-parent",
-		"--decorate=full",
-		GITFORMAT,
-	}
-	if branchName != "" {
-		args = append(args, branchName)
-	} else {
-		args = append(args, "--all")
-	}
-
-	logOut, err := gitCommand(args)
+	head, err := repo.Head()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to run git log")
+		return "", errors.Wrap(err, "failed to resolve mock repo HEAD")
 	}
-	logOut = fmt.Sprintf("[%s]", logOut) // Add []
-
-	var gitCommitObjList []gitCommitMock
-	json.Unmarshal([]byte(logOut), &gitCommitObjList)
 
-	return gitCommitObjList, err
+	return head.Name().Short(), nil
 }
 
-func describeHash(repoDir, commitHash string) (string, error) {
-	args := []string{
-		"-C",
-		repoDir,
-		"describe",
-		commitHash,
-		"--tags",
-		"--always",
-	}
-
-	logOut, err := gitCommand(args)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to run git describe")
-	}
-
-	return logOut, nil
-}
-
-func convertLogToRepositoryCommitList(gitCommitObjList []gitCommitMock) []*github.RepositoryCommit {
-	var commitsGithubApi []*github.RepositoryCommit
-	for _, commitObj := range gitCommitObjList {
-		shaString := new(string)
-		*shaString = commitObj.Commit
-
-		ghCommit := github.RepositoryCommit{
-			SHA: shaString,
+func toGitCommits(commitRefList []commitRef) []gitCommit {
+	var commits []gitCommit
+	for _, cr := range commitRefList {
+		var parents []string
+		for _, p := range cr.commit.ParentHashes {
+			parents = append(parents, p.String())
 		}
 
-		commitsGithubApi = append(commitsGithubApi, &ghCommit)
+		commits = append(commits, gitCommit{
+			SHA:       cr.commit.Hash.String(),
+			Parents:   parents,
+			Committer: cr.commit.Committer.Email,
+		})
 	}
 
-	return commitsGithubApi
+	return commits
 }
 
-func convertLogToReferenceList(gitCommitObjList []gitCommitMock, refsFilter string) []*github.Reference {
-	var RefTagsGithubApi []*github.Reference
-	for _, commitObj := range gitCommitObjList {
-		if strings.Contains(commitObj.Refs, refsFilter) {
-			RefTagsGithubApi = append(RefTagsGithubApi, getNewMockReference(&commitObj))
+func matchingRefs(commitRefList []commitRef, pattern string) []gitRef {
+	var refs []gitRef
+	for _, cr := range commitRefList {
+		for _, name := range cr.refs {
+			if strings.Contains(name, pattern) {
+				refs = append(refs, gitRef{Name: name, SHA: cr.commit.Hash.String()})
+			}
 		}
 	}
 
-	return RefTagsGithubApi
+	return refs
 }
 
-func getRefFromCommitObjList(gitCommitObjList []gitCommitMock, refName string) (*github.Reference, error) {
-	for _, commitObj := range gitCommitObjList {
-		if strings.Contains(commitObj.Refs, refName) {
-			return getNewMockReference(&commitObj), nil
+func refFromCommitRefList(commitRefList []commitRef, refName string) (gitRef, error) {
+	for _, cr := range commitRefList {
+		for _, name := range cr.refs {
+			if strings.Contains(name, refName) {
+				return gitRef{Name: name, SHA: cr.commit.Hash.String()}, nil
+			}
 		}
 	}
 
-	return nil, fmt.Errorf("reference %s not found", refName)
-}
-
-func getNewMockReference(commitObj *gitCommitMock) *github.Reference {
-	refString := new(string)
-	shaString := new(string)
-
-	// refactor tag name to fit githubApi format
-	commitObj.Refs = strings.Replace(commitObj.Refs, "tag: ", "", 1)
-	commitObj.Refs = strings.Replace(commitObj.Refs, "HEAD -> ", "", 1)
-
-	*refString = commitObj.Refs
-	*shaString = commitObj.Commit
-
-	ghReference := &github.Reference{
-		Ref: refString,
-		Object: &github.GitObject{
-			SHA: shaString,
-		},
-	}
-
-	return ghReference
+	return gitRef{}, fmt.Errorf("reference %s not found", refName)
 }
 
-// newFakeGithubApi creates a fake interface
-func newFakeGithubApi(repoDir string) *mockGithubApi {
-	return &mockGithubApi{
+// newFakeGitHost creates a provider-neutral fake backed by a local go-git
+// repository, standing in for whichever gitHost implementation (GitHub,
+// GitLab, Bitbucket) the component's URL would otherwise select.
+func newFakeGitHost(repoDir string) *mockGitHost {
+	return &mockGitHost{
 		repoDir: repoDir,
 	}
 }
 
-func newFakeGitComponent(api *mockGithubApi, repoDir string, componentParams *component, tagCommitMap map[string]string) *gitComponent {
+func newFakeGitComponent(host *mockGitHost, repoDir string, componentParams *component, tagCommitMap map[string]string) *gitComponent {
 	componentGitRepo := newLocalGitRepo(repoDir, tagCommitMap)
 
 	gitComponent := &gitComponent{
-		configParams:    componentParams,
-		githubInterface: api,
-		gitRepo:         componentGitRepo,
+		configParams: componentParams,
+		gitHost:      host,
+		gitRepo:      componentGitRepo,
 	}
 
 	return gitComponent
@@ -221,12 +151,14 @@ func initializeRepo(repo *git.Repository, repoDir string, tagCommitMap map[strin
 	Expect(err).ToNot(HaveOccurred(), "Should succeed getting repo Worktree")
 
 	createCommitWithoutTag(w, tagCommitMap, repoDir, "static", "master", false)
-	createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "tagged_annotated", "v0.0.1", "master")
+	createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "tagged_annotated", "v0.0.1", "master", nil)
+	createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "tagged_annotated_prerelease", "v0.0.2-rc1", "master", nil)
 	createCommitWithLightweightTag(w, repo, tagCommitMap, repoDir, "tagged_lightweight", "v0.0.2", "master")
 	createCommitWithoutTag(w, tagCommitMap, repoDir, "latest_master", "master", true)
 	createBranch(repo, "release-v1.0.0")
-	createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "tagged_annotated_branch", "v1.0.0", "release-v1.0.0")
+	createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "tagged_annotated_branch", "v1.0.0", "release-v1.0.0", nil)
 	createCommitWithLightweightTag(w, repo, tagCommitMap, repoDir, "tagged_lightweight_branch", "v1.0.1", "release-v1.0.0")
+	createCommitWithLightweightTag(w, repo, tagCommitMap, repoDir, "tagged_nightly_branch", "dev-20260101", "release-v1.0.0")
 	createCommitWithoutTag(w, tagCommitMap, repoDir, "latest_branch", "release-v1.0.0", true)
 	// adding a non-existing commit to check negative scenarios
 	tagCommitMap["dummy_false_commit"] = randstr.Hex(40)
@@ -237,13 +169,55 @@ func createBranch(repo *git.Repository, branchName string) {
 	headRef, err := repo.Head()
 	Expect(err).ToNot(HaveOccurred(), "Should succeed getting current Head ref")
 
-	ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName(branchName), headRef.Hash())
+	createBranchFrom(repo, branchName, headRef.Hash())
+}
+
+// createBranchFrom points a new branch at an arbitrary commit, rather
+// than always branching off the current Head.
+func createBranchFrom(repo *git.Repository, branchName string, hash plumbing.Hash) {
+	By(fmt.Sprintf("adding a new branch %s from %s", branchName, hash))
+	ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName(branchName), hash)
 
-	err = repo.Storer.SetReference(ref)
+	err := repo.Storer.SetReference(ref)
 	Expect(err).ToNot(HaveOccurred(), "Should succeed setting the branch ref")
 }
 
+// createMergeCommit commits a new file on intoBranch with mergeParent as
+// a second parent, so tests can exercise firstParent's exclusion of
+// commits only reachable through a merge.
+func createMergeCommit(w *git.Worktree, repo *git.Repository, repoDir, fileName, intoBranch string, mergeParent plumbing.Hash) plumbing.Hash {
+	By(fmt.Sprintf("merging %s into %s branch", mergeParent, intoBranch))
+	w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(intoBranch)})
+
+	headRef, err := repo.Head()
+	Expect(err).ToNot(HaveOccurred(), "Should succeed getting current Head ref")
+
+	fileWithPath := filepath.Join(repoDir, fileName)
+	err = ioutil.WriteFile(fileWithPath, []byte(""), 0644)
+	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Should succeed creating file %s", fileName))
+
+	_, err = w.Add(fileName)
+	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Should succeed adding %s file to repo tree", fileName))
+
+	commitHash, err := w.Commit(fmt.Sprintf("merge %s into %s", mergeParent, intoBranch), &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "John Doe",
+			Email: "john@doe.org",
+			When:  time.Now(),
+		},
+		Parents: []plumbing.Hash{headRef.Hash(), mergeParent},
+	})
+	Expect(err).ToNot(HaveOccurred(), "Should succeed committing merge commit")
+
+	return commitHash
+}
+
 func createCommit(w *git.Worktree, repoDir, fileName, branchName string) plumbing.Hash {
+	return createSignedCommit(w, repoDir, fileName, branchName, nil)
+}
+
+// createSignedCommit is createCommit with an optional GPG signing key.
+func createSignedCommit(w *git.Worktree, repoDir, fileName, branchName string, signKey *openpgp.Entity) plumbing.Hash {
 	By(fmt.Sprintf("committing a new file %s on %s branch", fileName, branchName))
 	w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branchName)})
 
@@ -260,6 +234,7 @@ func createCommit(w *git.Worktree, repoDir, fileName, branchName string) plumbin
 			Email: "john@doe.org",
 			When:  time.Now(),
 		},
+		SignKey: signKey,
 	})
 	Expect(err).ToNot(HaveOccurred(), "Should succeed committing to repo tree")
 
@@ -277,9 +252,12 @@ func createCommitWithoutTag(w *git.Worktree, tagCommitMap map[string]string, rep
 	}
 }
 
-func createCommitWithAnnotatedTag(w *git.Worktree, repo *git.Repository, tagCommitMap map[string]string, repoDir, fileName, tagName, branchName string) {
+// createCommitWithAnnotatedTag commits a new file and tags it with an
+// annotated tag, signing both the tip commit and the tag when signKey
+// is non-nil.
+func createCommitWithAnnotatedTag(w *git.Worktree, repo *git.Repository, tagCommitMap map[string]string, repoDir, fileName, tagName, branchName string, signKey *openpgp.Entity) {
 	By(fmt.Sprintf("committing a new file on %s branch with annotated tag", branchName))
-	commitHash := createCommit(w, repoDir, fileName, branchName)
+	commitHash := createSignedCommit(w, repoDir, fileName, branchName, signKey)
 
 	_, err := repo.CreateTag(tagName, commitHash, &git.CreateTagOptions{
 		Tagger: &object.Signature{
@@ -288,6 +266,7 @@ func createCommitWithAnnotatedTag(w *git.Worktree, repo *git.Repository, tagComm
 			When:  time.Now(),
 		},
 		Message: fileName,
+		SignKey: signKey,
 	})
 	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Should succeed adding %s tag to commit Hash %s", tagName, commitHash))
 
@@ -302,4 +281,33 @@ func createCommitWithLightweightTag(w *git.Worktree, repo *git.Repository, tagCo
 	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Should succeed adding %s tag to commit Hash %s", tagName, commitHash))
 
 	tagCommitMap[tagName] = commitHash.String()
+}
+
+// newTestSigningEntity generates a throwaway OpenPGP identity for
+// signing test commits and tags.
+func newTestSigningEntity() (*openpgp.Entity, error) {
+	entity, err := openpgp.NewEntity("bumper test signer", "", "bumper-test@example.org", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate test signing key")
+	}
+
+	return entity, nil
+}
+
+// writeArmoredPublicKey serializes entity's public key to keyPath in
+// armored form, as expected by a component's trusted_signers file.
+func writeArmoredPublicKey(entity *openpgp.Entity, keyPath string) error {
+	keyFile, err := os.Create(keyPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to create trusted signers file")
+	}
+	defer keyFile.Close()
+
+	armorWriter, err := armor.Encode(keyFile, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to open armor encoder")
+	}
+	defer armorWriter.Close()
+
+	return entity.Serialize(armorWriter)
 }
\ No newline at end of file
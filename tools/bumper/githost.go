@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// gitRef is a provider-neutral pointer to a commit, replacing go-github's
+// *github.Reference so gitComponent isn't tied to a single hosting API.
+type gitRef struct {
+	Name string
+	SHA  string
+}
+
+// gitCommit is a provider-neutral commit summary.
+type gitCommit struct {
+	SHA       string
+	Parents   []string
+	Committer string
+}
+
+// gitHost is the surface gitComponent needs from a remote git hosting
+// API: list refs matching a pattern, list commits reachable from a
+// branch, resolve a single ref to its commit, and look up the default
+// branch. Concrete implementations (githubHost, gitlabHost,
+// bitbucketCloudHost, bitbucketServerHost) each translate their own
+// API's types into gitRef/gitCommit so the rest of the bumper never
+// sees a provider-specific type; mockGitHost fakes the same surface
+// against a local go-git repository for tests.
+type gitHost interface {
+	ListMatchingRefs(pattern string) ([]gitRef, error)
+	ListCommits(branch string) ([]gitCommit, error)
+	GetRef(ref string) (gitRef, error)
+	DefaultBranch() (string, error)
+}
+
+// newGitHost selects a gitHost implementation for rawURL's hostname,
+// authenticating with token where the provider requires it. Any host
+// containing "bitbucket" other than bitbucket.org itself is treated as
+// a self-managed Bitbucket Server/Data Center instance, since those
+// don't have a fixed public hostname.
+func newGitHost(rawURL, token string) (gitHost, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse component url %q: %w", rawURL, err)
+	}
+
+	owner, repo, err := ownerAndRepo(parsed.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse owner/repo from url %q: %w", rawURL, err)
+	}
+
+	switch {
+	case strings.Contains(parsed.Host, "github.com"):
+		return newGithubHost(owner, repo, token), nil
+	case strings.Contains(parsed.Host, "gitlab.com"):
+		return newGitlabHost(owner, repo, token)
+	case parsed.Host == "bitbucket.org":
+		return newBitbucketCloudHost(owner, repo, token), nil
+	case strings.Contains(parsed.Host, "bitbucket"):
+		return newBitbucketServerHost(parsed.Scheme, parsed.Host, owner, repo, token), nil
+	default:
+		return nil, fmt.Errorf("unsupported git host %q", parsed.Host)
+	}
+}
+
+// ownerAndRepo splits a URL path like "/owner/repo" or "/owner/repo.git"
+// into its two components.
+func ownerAndRepo(urlPath string) (string, string, error) {
+	trimmed := strings.Trim(strings.TrimSuffix(urlPath, ".git"), "/")
+	parts := strings.Split(trimmed, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected /owner/repo, got %q", urlPath)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// filterRefsByPattern keeps only the refs whose name contains pattern,
+// matching the substring semantics the GitHub client already relied on
+// via ReferenceListOptions.Ref.
+func filterRefsByPattern(refs []gitRef, pattern string) []gitRef {
+	if pattern == "" {
+		return refs
+	}
+
+	var filtered []gitRef
+	for _, ref := range refs {
+		if strings.Contains(ref.Name, pattern) {
+			filtered = append(filtered, ref)
+		}
+	}
+
+	return filtered
+}
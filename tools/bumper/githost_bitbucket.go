@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// bitbucketGet performs an authenticated GET against url and decodes the
+// JSON response body into out, shared by both the Cloud and Server
+// implementations since neither has an official Go client.
+func bitbucketGet(token, url string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// bitbucketCloudHost implements gitHost against the Bitbucket Cloud
+// REST API (api.bitbucket.org/2.0).
+type bitbucketCloudHost struct {
+	owner string
+	repo  string
+	token string
+}
+
+func newBitbucketCloudHost(owner, repo, token string) *bitbucketCloudHost {
+	return &bitbucketCloudHost{owner: owner, repo: repo, token: token}
+}
+
+func (h *bitbucketCloudHost) fetchRefs(kind string) ([]gitRef, error) {
+	var refs []gitRef
+
+	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/refs/%s", h.owner, h.repo, kind)
+	for url != "" {
+		var page struct {
+			Values []struct {
+				Name   string `json:"name"`
+				Target struct {
+					Hash string `json:"hash"`
+				} `json:"target"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
+
+		if err := bitbucketGet(h.token, url, &page); err != nil {
+			return nil, err
+		}
+
+		for _, v := range page.Values {
+			refs = append(refs, gitRef{Name: v.Name, SHA: v.Target.Hash})
+		}
+
+		url = page.Next
+	}
+
+	return refs, nil
+}
+
+func (h *bitbucketCloudHost) ListMatchingRefs(pattern string) ([]gitRef, error) {
+	branches, err := h.fetchRefs("branches")
+	if err != nil {
+		return nil, fmt.Errorf("failed listing branches from bitbucket cloud: %w", err)
+	}
+
+	tags, err := h.fetchRefs("tags")
+	if err != nil {
+		return nil, fmt.Errorf("failed listing tags from bitbucket cloud: %w", err)
+	}
+
+	return filterRefsByPattern(append(branches, tags...), pattern), nil
+}
+
+func (h *bitbucketCloudHost) ListCommits(branch string) ([]gitCommit, error) {
+	var commits []gitCommit
+
+	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/commits/%s", h.owner, h.repo, branch)
+	for url != "" {
+		var page struct {
+			Values []struct {
+				Hash    string `json:"hash"`
+				Parents []struct {
+					Hash string `json:"hash"`
+				} `json:"parents"`
+				Author struct {
+					Raw string `json:"raw"`
+				} `json:"author"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
+
+		if err := bitbucketGet(h.token, url, &page); err != nil {
+			return nil, fmt.Errorf("failed listing commits from bitbucket cloud: %w", err)
+		}
+
+		for _, v := range page.Values {
+			var parents []string
+			for _, p := range v.Parents {
+				parents = append(parents, p.Hash)
+			}
+
+			commits = append(commits, gitCommit{SHA: v.Hash, Parents: parents, Committer: v.Author.Raw})
+		}
+
+		url = page.Next
+	}
+
+	return commits, nil
+}
+
+func (h *bitbucketCloudHost) GetRef(ref string) (gitRef, error) {
+	refs, err := h.ListMatchingRefs(ref)
+	if err != nil {
+		return gitRef{}, err
+	}
+
+	for _, r := range refs {
+		if r.Name == ref {
+			return r, nil
+		}
+	}
+
+	return gitRef{}, fmt.Errorf("reference %s not found on bitbucket cloud", ref)
+}
+
+func (h *bitbucketCloudHost) DefaultBranch() (string, error) {
+	var repo struct {
+		Mainbranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+
+	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s", h.owner, h.repo)
+	if err := bitbucketGet(h.token, url, &repo); err != nil {
+		return "", fmt.Errorf("failed fetching bitbucket cloud repository: %w", err)
+	}
+
+	return repo.Mainbranch.Name, nil
+}
+
+// bitbucketServerHost implements gitHost against a self-managed
+// Bitbucket Server/Data Center instance's REST API
+// (<baseURL>/rest/api/1.0).
+type bitbucketServerHost struct {
+	baseURL string
+	project string
+	repo    string
+	token   string
+}
+
+func newBitbucketServerHost(scheme, host, project, repo, token string) *bitbucketServerHost {
+	return &bitbucketServerHost{
+		baseURL: fmt.Sprintf("%s://%s/rest/api/1.0", scheme, host),
+		project: project,
+		repo:    repo,
+		token:   token,
+	}
+}
+
+func (h *bitbucketServerHost) fetchRefs(kind string) ([]gitRef, error) {
+	var refs []gitRef
+	start := 0
+
+	for {
+		var page struct {
+			Values []struct {
+				DisplayID    string `json:"displayId"`
+				LatestCommit string `json:"latestCommit"`
+			} `json:"values"`
+			IsLastPage    bool `json:"isLastPage"`
+			NextPageStart int  `json:"nextPageStart"`
+		}
+
+		url := fmt.Sprintf("%s/projects/%s/repos/%s/%s?start=%d", h.baseURL, h.project, h.repo, kind, start)
+		if err := bitbucketGet(h.token, url, &page); err != nil {
+			return nil, err
+		}
+
+		for _, v := range page.Values {
+			refs = append(refs, gitRef{Name: v.DisplayID, SHA: v.LatestCommit})
+		}
+
+		if page.IsLastPage {
+			break
+		}
+		start = page.NextPageStart
+	}
+
+	return refs, nil
+}
+
+func (h *bitbucketServerHost) ListMatchingRefs(pattern string) ([]gitRef, error) {
+	branches, err := h.fetchRefs("branches")
+	if err != nil {
+		return nil, fmt.Errorf("failed listing branches from bitbucket server: %w", err)
+	}
+
+	tags, err := h.fetchRefs("tags")
+	if err != nil {
+		return nil, fmt.Errorf("failed listing tags from bitbucket server: %w", err)
+	}
+
+	return filterRefsByPattern(append(branches, tags...), pattern), nil
+}
+
+func (h *bitbucketServerHost) ListCommits(branch string) ([]gitCommit, error) {
+	var commits []gitCommit
+	start := 0
+
+	for {
+		var page struct {
+			Values []struct {
+				ID      string `json:"id"`
+				Parents []struct {
+					ID string `json:"id"`
+				} `json:"parents"`
+				Committer struct {
+					EmailAddress string `json:"emailAddress"`
+				} `json:"committer"`
+			} `json:"values"`
+			IsLastPage    bool `json:"isLastPage"`
+			NextPageStart int  `json:"nextPageStart"`
+		}
+
+		url := fmt.Sprintf("%s/projects/%s/repos/%s/commits?until=%s&start=%d", h.baseURL, h.project, h.repo, branch, start)
+		if err := bitbucketGet(h.token, url, &page); err != nil {
+			return nil, fmt.Errorf("failed listing commits from bitbucket server: %w", err)
+		}
+
+		for _, v := range page.Values {
+			var parents []string
+			for _, p := range v.Parents {
+				parents = append(parents, p.ID)
+			}
+
+			commits = append(commits, gitCommit{SHA: v.ID, Parents: parents, Committer: v.Committer.EmailAddress})
+		}
+
+		if page.IsLastPage {
+			break
+		}
+		start = page.NextPageStart
+	}
+
+	return commits, nil
+}
+
+func (h *bitbucketServerHost) GetRef(ref string) (gitRef, error) {
+	refs, err := h.ListMatchingRefs(ref)
+	if err != nil {
+		return gitRef{}, err
+	}
+
+	for _, r := range refs {
+		if r.Name == ref {
+			return r, nil
+		}
+	}
+
+	return gitRef{}, fmt.Errorf("reference %s not found on bitbucket server", ref)
+}
+
+func (h *bitbucketServerHost) DefaultBranch() (string, error) {
+	var branch struct {
+		DisplayID string `json:"displayId"`
+	}
+
+	url := fmt.Sprintf("%s/projects/%s/repos/%s/branches/default", h.baseURL, h.project, h.repo)
+	if err := bitbucketGet(h.token, url, &branch); err != nil {
+		return "", fmt.Errorf("failed fetching bitbucket server default branch: %w", err)
+	}
+
+	return branch.DisplayID, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v32/github"
+	"golang.org/x/oauth2"
+)
+
+// githubHost implements gitHost against the real GitHub API.
+type githubHost struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+func newGithubHost(owner, repo, token string) *githubHost {
+	var httpClient *http.Client
+	if token != "" {
+		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+	}
+
+	return &githubHost{
+		client: github.NewClient(httpClient),
+		owner:  owner,
+		repo:   repo,
+	}
+}
+
+func (h *githubHost) ListMatchingRefs(pattern string) ([]gitRef, error) {
+	var result []gitRef
+	opts := &github.ReferenceListOptions{Ref: pattern, ListOptions: github.ListOptions{PerPage: 100}}
+
+	for {
+		refs, resp, err := h.client.Git.ListMatchingRefs(context.Background(), h.owner, h.repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed listing refs from github: %w", err)
+		}
+
+		for _, ref := range refs {
+			result = append(result, gitRef{Name: ref.GetRef(), SHA: ref.GetObject().GetSHA()})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	return result, nil
+}
+
+func (h *githubHost) ListCommits(branch string) ([]gitCommit, error) {
+	var result []gitCommit
+	opts := &github.CommitsListOptions{SHA: branch, ListOptions: github.ListOptions{PerPage: 100}}
+
+	for {
+		commits, resp, err := h.client.Repositories.ListCommits(context.Background(), h.owner, h.repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed listing commits from github: %w", err)
+		}
+
+		for _, c := range commits {
+			var parents []string
+			for _, p := range c.Parents {
+				parents = append(parents, p.GetSHA())
+			}
+
+			result = append(result, gitCommit{
+				SHA:       c.GetSHA(),
+				Parents:   parents,
+				Committer: c.GetCommit().GetCommitter().GetEmail(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	return result, nil
+}
+
+func (h *githubHost) GetRef(ref string) (gitRef, error) {
+	r, _, err := h.client.Git.GetRef(context.Background(), h.owner, h.repo, ref)
+	if err != nil {
+		return gitRef{}, fmt.Errorf("failed resolving ref %q from github: %w", ref, err)
+	}
+
+	return gitRef{Name: r.GetRef(), SHA: r.GetObject().GetSHA()}, nil
+}
+
+func (h *githubHost) DefaultBranch() (string, error) {
+	r, _, err := h.client.Repositories.Get(context.Background(), h.owner, h.repo)
+	if err != nil {
+		return "", fmt.Errorf("failed fetching github repository: %w", err)
+	}
+
+	return r.GetDefaultBranch(), nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// gitlabHost implements gitHost against the GitLab API.
+type gitlabHost struct {
+	client  *gitlab.Client
+	project string
+}
+
+func newGitlabHost(owner, repo, token string) (*gitlabHost, error) {
+	client, err := gitlab.NewClient(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gitlab client: %w", err)
+	}
+
+	return &gitlabHost{
+		client:  client,
+		project: owner + "/" + repo,
+	}, nil
+}
+
+func (h *gitlabHost) ListMatchingRefs(pattern string) ([]gitRef, error) {
+	branches, _, err := h.client.Branches.ListBranches(h.project, &gitlab.ListBranchesOptions{Search: gitlab.String(pattern)})
+	if err != nil {
+		return nil, fmt.Errorf("failed listing branches from gitlab: %w", err)
+	}
+
+	tags, _, err := h.client.Tags.ListTags(h.project, &gitlab.ListTagsOptions{Search: gitlab.String(pattern)})
+	if err != nil {
+		return nil, fmt.Errorf("failed listing tags from gitlab: %w", err)
+	}
+
+	var refs []gitRef
+	for _, b := range branches {
+		refs = append(refs, gitRef{Name: b.Name, SHA: b.Commit.ID})
+	}
+	for _, t := range tags {
+		refs = append(refs, gitRef{Name: t.Name, SHA: t.Commit.ID})
+	}
+
+	return refs, nil
+}
+
+func (h *gitlabHost) ListCommits(branch string) ([]gitCommit, error) {
+	commits, _, err := h.client.Commits.ListCommits(h.project, &gitlab.ListCommitsOptions{RefName: gitlab.String(branch)})
+	if err != nil {
+		return nil, fmt.Errorf("failed listing commits from gitlab: %w", err)
+	}
+
+	var result []gitCommit
+	for _, c := range commits {
+		result = append(result, gitCommit{SHA: c.ID, Parents: c.ParentIDs, Committer: c.CommitterEmail})
+	}
+
+	return result, nil
+}
+
+func (h *gitlabHost) GetRef(ref string) (gitRef, error) {
+	if branch, _, err := h.client.Branches.GetBranch(h.project, ref); err == nil {
+		return gitRef{Name: branch.Name, SHA: branch.Commit.ID}, nil
+	}
+
+	tag, _, err := h.client.Tags.GetTag(h.project, ref)
+	if err != nil {
+		return gitRef{}, fmt.Errorf("failed resolving ref %q from gitlab: %w", ref, err)
+	}
+
+	return gitRef{Name: tag.Name, SHA: tag.Commit.ID}, nil
+}
+
+func (h *gitlabHost) DefaultBranch() (string, error) {
+	project, _, err := h.client.Projects.GetProject(h.project, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed fetching gitlab project: %w", err)
+	}
+
+	return project.DefaultBranch, nil
+}
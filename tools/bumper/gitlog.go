@@ -0,0 +1,143 @@
+package main
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+)
+
+// commitRef pairs a walked commit with the short names of every ref
+// (branch or tag) that points at it.
+type commitRef struct {
+	commit *object.Commit
+	refs   []string
+}
+
+// walkFirstParentLog returns every commit reachable from `from` by
+// following only first parents, closest first — the native equivalent
+// of `git log --first-parent`. Unlike repo.Log walking the full
+// ancestor graph, this never pulls in commits that were only merged
+// into the branch.
+func walkFirstParentLog(repo *git.Repository, from plumbing.Hash) ([]*object.Commit, error) {
+	var commits []*object.Commit
+
+	for hash := from; hash != plumbing.ZeroHash; {
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+
+		if commit.NumParents() == 0 {
+			break
+		}
+		hash = commit.ParentHashes[0]
+	}
+
+	return commits, nil
+}
+
+// gitLog walks repoDir natively via go-git, listing the commits
+// `git log --first-parent [--all|branchName]` used to produce, but
+// returning commitRef values (with short ref names) directly instead of
+// round-tripping through --pretty=format JSON.
+func gitLog(repoDir, branchName string) ([]commitRef, error) {
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open repo")
+	}
+
+	refsByHash, err := buildRefsByHash(repo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to index repo refs")
+	}
+
+	startingPoints, err := logStartingPoints(repo, branchName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve log starting point")
+	}
+
+	visited := map[plumbing.Hash]bool{}
+	var commitRefList []commitRef
+	for _, from := range startingPoints {
+		commits, err := walkFirstParentLog(repo, from)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to walk commit log")
+		}
+
+		for _, c := range commits {
+			if visited[c.Hash] {
+				continue
+			}
+			visited[c.Hash] = true
+			commitRefList = append(commitRefList, commitRef{commit: c, refs: refsByHash[c.Hash]})
+		}
+	}
+
+	return commitRefList, nil
+}
+
+// logStartingPoints resolves the commit(s) a walk should start from: the
+// named branch when one is given, or every reference's tip when
+// branchName is empty, mirroring `git log --all`.
+func logStartingPoints(repo *git.Repository, branchName string) ([]plumbing.Hash, error) {
+	if branchName != "" {
+		ref, err := repo.Reference(plumbing.NewBranchReferenceName(branchName), true)
+		if err != nil {
+			return nil, err
+		}
+		return []plumbing.Hash{ref.Hash()}, nil
+	}
+
+	refIter, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+	defer refIter.Close()
+
+	var hashes []plumbing.Hash
+	err = refIter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		hashes = append(hashes, resolveToCommitHash(repo, ref.Hash()))
+		return nil
+	})
+
+	return hashes, err
+}
+
+// buildRefsByHash indexes every branch and tag in the repo by the hash
+// of the commit it ultimately points at, resolving annotated tag
+// objects to their target commit.
+func buildRefsByHash(repo *git.Repository) (map[plumbing.Hash][]string, error) {
+	refsByHash := map[plumbing.Hash][]string{}
+
+	refIter, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+	defer refIter.Close()
+
+	err = refIter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		hash := resolveToCommitHash(repo, ref.Hash())
+		refsByHash[hash] = append(refsByHash[hash], ref.Name().Short())
+		return nil
+	})
+
+	return refsByHash, err
+}
+
+// resolveToCommitHash follows an annotated tag object to the commit it
+// targets, or returns the hash unchanged when it already names a commit.
+func resolveToCommitHash(repo *git.Repository, hash plumbing.Hash) plumbing.Hash {
+	if tagObj, err := repo.TagObject(hash); err == nil {
+		return tagObj.Target
+	}
+	return hash
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("mockGitHost", func() {
+	var (
+		repoDir      string
+		host         *mockGitHost
+		tagCommitMap map[string]string
+	)
+
+	BeforeEach(func() {
+		var err error
+		repoDir, err = ioutil.TempDir("", "bumper-mockgithost-test")
+		Expect(err).ToNot(HaveOccurred())
+
+		tagCommitMap = map[string]string{}
+		newLocalGitRepo(repoDir, tagCommitMap)
+		host = newFakeGitHost(repoDir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(repoDir)).To(Succeed())
+	})
+
+	It("lists refs whose name contains the given pattern", func() {
+		refs, err := host.ListMatchingRefs("v0.0")
+		Expect(err).ToNot(HaveOccurred())
+
+		var names []string
+		for _, ref := range refs {
+			names = append(names, ref.Name)
+		}
+		Expect(names).To(ContainElement("v0.0.1"))
+		Expect(names).To(ContainElement("v0.0.2"))
+		Expect(names).To(ContainElement("v0.0.2-rc1"))
+	})
+
+	It("lists commits reachable from a branch, each carrying its parent SHAs", func() {
+		commits, err := host.ListCommits("master")
+		Expect(err).ToNot(HaveOccurred())
+
+		bySHA := map[string]gitCommit{}
+		for _, c := range commits {
+			bySHA[c.SHA] = c
+		}
+
+		tip, ok := bySHA[tagCommitMap["dummy_tag_latest_master"]]
+		Expect(ok).To(BeTrue())
+		Expect(tip.Parents).To(HaveLen(1))
+		Expect(tip.Parents[0]).To(Equal(tagCommitMap["v0.0.2"]))
+	})
+
+	It("only lists commits reachable from the requested branch", func() {
+		masterCommits, err := host.ListCommits("master")
+		Expect(err).ToNot(HaveOccurred())
+
+		var masterSHAs []string
+		for _, c := range masterCommits {
+			masterSHAs = append(masterSHAs, c.SHA)
+		}
+		Expect(masterSHAs).ToNot(ContainElement(tagCommitMap["v1.0.0"]))
+	})
+
+	It("resolves a single ref by name", func() {
+		ref, err := host.GetRef("v0.0.1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ref.SHA).To(Equal(tagCommitMap["v0.0.1"]))
+	})
+
+	It("errors resolving a ref that doesn't exist", func() {
+		_, err := host.GetRef("does-not-exist")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("reports the currently checked out branch as the default branch", func() {
+		branch, err := host.DefaultBranch()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(branch).To(Equal("release-v1.0.0"))
+	})
+})
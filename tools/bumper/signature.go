@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+)
+
+// loadTrustedSigners reads the armored OpenPGP public keyring a
+// component references via trusted_signers, used to verify signed
+// tags/commits before a bump is accepted.
+func loadTrustedSigners(path string) (string, error) {
+	keyring, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read trusted signers file %s", path)
+	}
+
+	return string(keyring), nil
+}
+
+// verifyBump enforces cfg's require_signed_tag/require_signed_commit
+// flags against the tag and tip commit the bumper is about to adopt,
+// refusing the bump when a required signature doesn't verify against
+// cfg.TrustedSigners. With require_signed_tag set, an empty tagName
+// fails to resolve and the bump is refused.
+func verifyBump(cfg *component, repo *gitRepo, tagName string, commitHash plumbing.Hash) error {
+	if !cfg.RequireSignedTag && !cfg.RequireSignedCommit {
+		return nil
+	}
+
+	keyring, err := loadTrustedSigners(cfg.TrustedSigners)
+	if err != nil {
+		return err
+	}
+
+	if cfg.RequireSignedTag {
+		if err := verifyTag(repo, tagName, keyring); err != nil {
+			return err
+		}
+	}
+
+	if cfg.RequireSignedCommit {
+		if err := verifyCommit(repo, commitHash, keyring); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyTag checks that tagName is an annotated tag whose signature
+// verifies against keyring, refusing lightweight tags outright since
+// they carry no signature to check.
+func verifyTag(repo *gitRepo, tagName string, keyring string) error {
+	ref, err := repo.repo.Tag(tagName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resolve tag %s", tagName)
+	}
+
+	tagObj, err := repo.repo.TagObject(ref.Hash())
+	if err != nil {
+		return fmt.Errorf("tag %s is lightweight but require_signed_tag is set", tagName)
+	}
+
+	if _, err := tagObj.Verify(keyring); err != nil {
+		return errors.Wrapf(err, "tag %s signature did not verify against trusted signers", tagName)
+	}
+
+	return nil
+}
+
+// verifyCommit checks that commitHash's signature verifies against
+// keyring.
+func verifyCommit(repo *gitRepo, commitHash plumbing.Hash, keyring string) error {
+	commit, err := repo.repo.CommitObject(commitHash)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resolve commit %s", commitHash)
+	}
+
+	if _, err := commit.Verify(keyring); err != nil {
+		return errors.Wrapf(err, "commit %s signature did not verify against trusted signers", commitHash)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"golang.org/x/crypto/openpgp"
+)
+
+var _ = Describe("signature verification", func() {
+	var (
+		repoDir        string
+		repo           *git.Repository
+		w              *git.Worktree
+		wrappedRepo    *gitRepo
+		trustedEntity  *openpgp.Entity
+		trustedKeyring string
+		tagCommitMap   map[string]string
+	)
+
+	BeforeEach(func() {
+		var err error
+		repoDir, err = ioutil.TempDir("", "bumper-signature-test")
+		Expect(err).ToNot(HaveOccurred())
+
+		repo, err = git.PlainInit(repoDir, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		w, err = repo.Worktree()
+		Expect(err).ToNot(HaveOccurred())
+
+		wrappedRepo = &gitRepo{repo: repo, localDir: repoDir}
+		tagCommitMap = map[string]string{}
+
+		trustedEntity, err = newTestSigningEntity()
+		Expect(err).ToNot(HaveOccurred())
+
+		keyPath := filepath.Join(repoDir, "trusted_signers.asc")
+		Expect(writeArmoredPublicKey(trustedEntity, keyPath)).To(Succeed())
+
+		trustedKeyring, err = loadTrustedSigners(keyPath)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(repoDir)).To(Succeed())
+	})
+
+	Context("require_signed_tag", func() {
+		It("accepts an annotated tag signed by a trusted key", func() {
+			createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "trusted", "v1.0.0", "master", trustedEntity)
+
+			Expect(verifyTag(wrappedRepo, "v1.0.0", trustedKeyring)).To(Succeed())
+		})
+
+		It("rejects an unsigned annotated tag", func() {
+			createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "unsigned", "v1.0.1", "master", nil)
+
+			Expect(verifyTag(wrappedRepo, "v1.0.1", trustedKeyring)).ToNot(Succeed())
+		})
+
+		It("rejects a tag signed by an untrusted key", func() {
+			untrustedEntity, err := newTestSigningEntity()
+			Expect(err).ToNot(HaveOccurred())
+
+			createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "untrusted", "v1.0.2", "master", untrustedEntity)
+
+			Expect(verifyTag(wrappedRepo, "v1.0.2", trustedKeyring)).ToNot(Succeed())
+		})
+
+		It("rejects a lightweight tag when annotated signing is required", func() {
+			createCommitWithLightweightTag(w, repo, tagCommitMap, repoDir, "lightweight", "v1.0.3", "master")
+
+			Expect(verifyTag(wrappedRepo, "v1.0.3", trustedKeyring)).ToNot(Succeed())
+		})
+	})
+
+	Context("require_signed_commit", func() {
+		It("accepts a tip commit signed by a trusted key", func() {
+			hash := createSignedCommit(w, repoDir, "signed_commit", "master", trustedEntity)
+
+			Expect(verifyCommit(wrappedRepo, hash, trustedKeyring)).To(Succeed())
+		})
+
+		It("rejects an unsigned tip commit", func() {
+			hash := createCommit(w, repoDir, "unsigned_commit", "master")
+
+			Expect(verifyCommit(wrappedRepo, hash, trustedKeyring)).ToNot(Succeed())
+		})
+	})
+
+	It("leaves verifyBump a no-op when neither flag is set", func() {
+		createCommitWithAnnotatedTag(w, repo, tagCommitMap, repoDir, "unsigned", "v2.0.0", "master", nil)
+
+		cfg := &component{Name: "example"}
+		Expect(verifyBump(cfg, wrappedRepo, "v2.0.0", plumbing.NewHash(tagCommitMap["v2.0.0"]))).To(Succeed())
+	})
+})